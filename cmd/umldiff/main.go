@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bearing-actions/bearing-actions-go/uml"
+)
+
+func main() {
+	var (
+		indent    = flag.String("indent", "  ", "JSON indent for the report (empty for compact)")
+		allowPath = flag.String("allow", "", "file listing intentionally allowed breaking changes (\"<import path> <symbol>\" per line)")
+		outPath   = flag.String("o", "", "write report to file (default: stdout)")
+	)
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: umldiff [flags] <old> <new>")
+		fmt.Fprintln(os.Stderr, "  <old> and <new> are each either a uml JSON file or a source directory")
+		os.Exit(2)
+	}
+
+	oldModel, err := loadModel(flag.Arg(0))
+	if err != nil {
+		fatalf("load old: %v", err)
+	}
+	newModel, err := loadModel(flag.Arg(1))
+	if err != nil {
+		fatalf("load new: %v", err)
+	}
+
+	report := uml.Diff(oldModel, newModel)
+
+	var allow map[string]map[string]bool
+	if *allowPath != "" {
+		allow, err = loadAllowlist(*allowPath)
+		if err != nil {
+			fatalf("load allow file: %v", err)
+		}
+	}
+	breaking := applyAllowlist(&report, allow)
+
+	var data []byte
+	if *indent == "" {
+		data, err = json.Marshal(report)
+	} else {
+		data, err = json.MarshalIndent(report, "", *indent)
+	}
+	if err != nil {
+		fatalf("marshal report: %v", err)
+	}
+	data = append(data, '\n')
+
+	if *outPath == "" {
+		_, _ = os.Stdout.Write(data)
+	} else if err := os.WriteFile(*outPath, data, 0o644); err != nil {
+		fatalf("write %s: %v", *outPath, err)
+	}
+
+	if breaking {
+		os.Exit(1)
+	}
+}
+
+func loadModel(path string) (*uml.Model, error) {
+	if strings.HasSuffix(path, ".json") {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var m uml.Model
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+		}
+		return &m, nil
+	}
+	return uml.Generate(path, uml.Options{})
+}
+
+// loadAllowlist reads "<import path> <symbol>" lines (blank lines and lines
+// starting with "#" ignored) into a per-package set of allowed symbols,
+// matching the workflow of upstream cmd/api's -allow_new file.
+func loadAllowlist(path string) (map[string]map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	allow := map[string]map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if allow[parts[0]] == nil {
+			allow[parts[0]] = map[string]bool{}
+		}
+		allow[parts[0]][parts[1]] = true
+	}
+	return allow, scanner.Err()
+}
+
+// applyAllowlist clears Breaking on every allow-listed change and reports
+// whether any breaking change remains.
+func applyAllowlist(report *uml.Report, allow map[string]map[string]bool) bool {
+	breaking := false
+	for pi := range report.Packages {
+		pkg := &report.Packages[pi]
+		for ci := range pkg.Changes {
+			c := &pkg.Changes[ci]
+			if !c.Breaking {
+				continue
+			}
+			if allow[pkg.ImportPath][c.Symbol] {
+				c.Breaking = false
+				continue
+			}
+			breaking = true
+		}
+	}
+	return breaking
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -11,11 +12,16 @@ import (
 
 func main() {
 	var (
-		includeTests     = flag.Bool("tests", false, "include *_test.go files")
-		includeGenerated = flag.Bool("generated", false, "include files with \"Code generated\" headers")
-		indent           = flag.String("indent", "  ", "JSON indent (empty for compact)")
-		outPath          = flag.String("o", "", "write output to file (default: stdout)")
-		exclude          = flag.String("exclude", "", "comma-separated dir names to skip (overrides defaults when set)")
+		includeTests      = flag.Bool("tests", false, "include *_test.go files")
+		includeGenerated  = flag.Bool("generated", false, "include files with \"Code generated\" headers")
+		indent            = flag.String("indent", "  ", "JSON indent (empty for compact)")
+		outPath           = flag.String("o", "", "write output to file (default: stdout)")
+		exclude           = flag.String("exclude", "", "comma-separated dir names to skip (overrides defaults when set)")
+		format            = flag.String("format", "json", "output format: json, plantuml, mermaid")
+		includeUnexported = flag.Bool("unexported", false, "(plantuml/mermaid) include unexported fields and methods")
+		includeSignatures = flag.Bool("signatures", false, "(plantuml/mermaid) emit full method signatures")
+		groupByPackage    = flag.Bool("group-by-package", false, "(plantuml) wrap each package's types in a package block")
+		typed             = flag.Bool("typed", true, "type-check with go/types to fill in qualified types and (plantuml/mermaid) interface-implementation edges; disable for speed")
 	)
 	flag.Parse()
 
@@ -33,7 +39,11 @@ func main() {
 		opts.ExcludeDirNames = splitCommaList(*exclude)
 	}
 
-	data, err := uml.GenerateJSON(path, opts)
+	data, err := render(path, opts, *format, *typed, uml.RenderOptions{
+		IncludeUnexported: *includeUnexported,
+		IncludeSignatures: *includeSignatures,
+		GroupByPackage:    *groupByPackage,
+	})
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -50,6 +60,36 @@ func main() {
 	}
 }
 
+func render(path string, opts uml.Options, format string, typed bool, renderOpts uml.RenderOptions) ([]byte, error) {
+	generate := uml.Generate
+	if typed {
+		generate = uml.GenerateTyped
+	}
+
+	switch format {
+	case "", "json":
+		model, err := generate(path, opts)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Indent == "" {
+			return json.Marshal(model)
+		}
+		return json.MarshalIndent(model, "", opts.Indent)
+	case "plantuml", "mermaid":
+		model, err := generate(path, opts)
+		if err != nil {
+			return nil, err
+		}
+		if format == "plantuml" {
+			return uml.RenderPlantUML(model, renderOpts)
+		}
+		return uml.RenderMermaid(model, renderOpts)
+	default:
+		return nil, fmt.Errorf("unknown -format %q: want json, plantuml, or mermaid", format)
+	}
+}
+
 func splitCommaList(value string) []string {
 	parts := strings.Split(value, ",")
 	out := make([]string, 0, len(parts))
@@ -0,0 +1,96 @@
+package uml
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+)
+
+// BuildContext is a GOOS/GOARCH/cgo combination used to evaluate build
+// constraints the same way "go build" would for that platform, so that
+// platform-specific files and declarations are neither silently dropped nor
+// wrongly merged with the rest of the package.
+type BuildContext struct {
+	GOOS       string
+	GOARCH     string
+	CgoEnabled bool
+}
+
+// String returns a short label such as "linux/amd64" or "linux/amd64+cgo",
+// used to tag Files, Types, and Functions observed under this context.
+func (c BuildContext) String() string {
+	s := c.GOOS + "/" + c.GOARCH
+	if c.CgoEnabled {
+		s += "+cgo"
+	}
+	return s
+}
+
+func (c BuildContext) toBuildContext() build.Context {
+	bc := build.Default
+	bc.GOOS = c.GOOS
+	bc.GOARCH = c.GOARCH
+	bc.CgoEnabled = c.CgoEnabled
+	return bc
+}
+
+// DefaultContexts returns the matrix of platforms Generate evaluates build
+// constraints against when Options.Contexts is left unset: the common
+// desktop/server OSes, each with and without cgo where that combination is
+// meaningful.
+func DefaultContexts() []BuildContext {
+	return []BuildContext{
+		{GOOS: "linux", GOARCH: "amd64", CgoEnabled: true},
+		{GOOS: "linux", GOARCH: "amd64", CgoEnabled: false},
+		{GOOS: "linux", GOARCH: "arm64", CgoEnabled: false},
+		{GOOS: "darwin", GOARCH: "amd64", CgoEnabled: true},
+		{GOOS: "darwin", GOARCH: "arm64", CgoEnabled: true},
+		{GOOS: "windows", GOARCH: "amd64", CgoEnabled: false},
+	}
+}
+
+// matchFiles returns the subset of candidates that bc's build constraints
+// (GOOS/GOARCH filename suffixes, //go:build lines, and cgo) allow.
+func matchFiles(dir string, candidates []string, bc BuildContext) []string {
+	ctx := bc.toBuildContext()
+
+	var out []string
+	for _, name := range candidates {
+		match, err := ctx.MatchFile(dir, name)
+		if err != nil || !match {
+			continue
+		}
+
+		// MatchFile only honors CgoEnabled via an explicit "//go:build cgo"
+		// tag; it doesn't notice a plain `import "C"`, which go/build.Import
+		// excludes from a cgo-disabled build regardless of tags. Without
+		// this, a cgo file with no tag of its own would match every context.
+		cgo, err := isCgoFile(dir, name)
+		if err != nil {
+			continue
+		}
+		if cgo && !bc.CgoEnabled {
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// isCgoFile reports whether the named file directly imports "C", the
+// signal go/build.Import uses to classify a CgoFile independent of any
+// build constraint the file itself declares.
+func isCgoFile(dir, name string) (bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ImportsOnly)
+	if err != nil {
+		return false, err
+	}
+	for _, imp := range f.Imports {
+		if imp.Path.Value == `"C"` {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,55 @@
+package uml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchFilesExcludesUntaggedCgoFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "cgo.go", `package fixture
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+type Widget struct{ Size int }
+`)
+	writeFile(t, dir, "plain.go", `package fixture
+
+type Other struct{ Name string }
+`)
+
+	candidates := []string{"cgo.go", "plain.go"}
+
+	cgoOn := matchFiles(dir, candidates, BuildContext{GOOS: "linux", GOARCH: "amd64", CgoEnabled: true})
+	if !containsString(cgoOn, "cgo.go") || !containsString(cgoOn, "plain.go") {
+		t.Errorf("cgo-enabled context should match both files, got %v", cgoOn)
+	}
+
+	cgoOff := matchFiles(dir, candidates, BuildContext{GOOS: "linux", GOARCH: "amd64", CgoEnabled: false})
+	if containsString(cgoOff, "cgo.go") {
+		t.Errorf("cgo-disabled context should exclude an untagged cgo file, got %v", cgoOff)
+	}
+	if !containsString(cgoOff, "plain.go") {
+		t.Errorf("cgo-disabled context should still match a plain file, got %v", cgoOff)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,348 @@
+package uml
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChangeKind classifies a single difference found by Diff.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is one added, removed, or changed exported symbol found by Diff.
+// Breaking is only meaningful for ChangeRemoved and ChangeChanged, and for
+// the rare ChangeAdded that widens an interface's method set.
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Symbol   string     `json:"symbol"`
+	Breaking bool       `json:"breaking,omitempty"`
+	Detail   string     `json:"detail,omitempty"`
+}
+
+// PackageDiff is every Change found within one import path.
+type PackageDiff struct {
+	ImportPath string   `json:"import_path"`
+	Changes    []Change `json:"changes"`
+}
+
+// Report is the result of Diff: one PackageDiff per import path touched by
+// either model, sorted by import path so it's stable regardless of either
+// Model's package ordering.
+type Report struct {
+	Packages []PackageDiff `json:"packages"`
+}
+
+// Breaking reports whether the report contains any breaking change.
+// cmd/umldiff does not call it; it reaches its exit code while applying its
+// -allow file instead.
+func (r Report) Breaking() bool {
+	for _, pkg := range r.Packages {
+		for _, c := range pkg.Changes {
+			if c.Breaking {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Diff compares the exported API surface of old and new, name-keyed within
+// each ImportPath so the result does not depend on declaration order in
+// either Model.
+func Diff(old, new *Model) Report {
+	oldPkgs := packagesByImportPath(old)
+	newPkgs := packagesByImportPath(new)
+
+	var report Report
+	for _, path := range unionNames(oldPkgs, newPkgs) {
+		changes := diffPackage(oldPkgs[path], newPkgs[path])
+		if len(changes) == 0 {
+			continue
+		}
+		report.Packages = append(report.Packages, PackageDiff{ImportPath: path, Changes: changes})
+	}
+	return report
+}
+
+func diffPackage(oldPkg, newPkg Package) []Change {
+	var changes []Change
+
+	oldTypes := typesByName(oldPkg)
+	newTypes := typesByName(newPkg)
+	for _, name := range unionNames(oldTypes, newTypes) {
+		ot, oldOK := oldTypes[name]
+		nt, newOK := newTypes[name]
+		switch {
+		case oldOK && !newOK:
+			if ot.Exported {
+				changes = append(changes, Change{Kind: ChangeRemoved, Symbol: "type " + name, Breaking: true})
+			}
+		case !oldOK && newOK:
+			if nt.Exported {
+				changes = append(changes, Change{Kind: ChangeAdded, Symbol: "type " + name})
+			}
+		default:
+			if ot.Exported || nt.Exported {
+				changes = append(changes, diffType(name, ot, nt)...)
+			}
+		}
+	}
+
+	oldFuncs := functionsByName(oldPkg.Functions)
+	newFuncs := functionsByName(newPkg.Functions)
+	for _, name := range unionNames(oldFuncs, newFuncs) {
+		of, oldOK := oldFuncs[name]
+		nf, newOK := newFuncs[name]
+		symbol := "func " + name
+		switch {
+		case oldOK && !newOK:
+			if of.Exported {
+				changes = append(changes, Change{Kind: ChangeRemoved, Symbol: symbol, Breaking: true})
+			}
+		case !oldOK && newOK:
+			if nf.Exported {
+				changes = append(changes, Change{Kind: ChangeAdded, Symbol: symbol})
+			}
+		default:
+			if (of.Exported || nf.Exported) && signatureChanged(of, nf) {
+				changes = append(changes, Change{Kind: ChangeChanged, Symbol: symbol, Breaking: true, Detail: "signature change"})
+			}
+		}
+	}
+
+	changes = append(changes, diffValues("const", valuesByName(oldPkg.Constants), valuesByName(newPkg.Constants))...)
+	changes = append(changes, diffValues("var", valuesByName(oldPkg.Variables), valuesByName(newPkg.Variables))...)
+
+	return changes
+}
+
+// diffValues compares a package's const or var declarations by name. label
+// ("const" or "var") only distinguishes the two in the reported Symbol;
+// removing an exported value or changing its type is breaking the same way
+// a field or signature change is.
+func diffValues(label string, oldByName, newByName map[string]Value) []Change {
+	var changes []Change
+	for _, name := range unionNames(oldByName, newByName) {
+		ov, oldOK := oldByName[name]
+		nv, newOK := newByName[name]
+		symbol := label + " " + name
+		switch {
+		case oldOK && !newOK:
+			if ov.Exported {
+				changes = append(changes, Change{Kind: ChangeRemoved, Symbol: symbol, Breaking: true})
+			}
+		case !oldOK && newOK:
+			if nv.Exported {
+				changes = append(changes, Change{Kind: ChangeAdded, Symbol: symbol})
+			}
+		default:
+			if (ov.Exported || nv.Exported) && ov.Type != nv.Type {
+				changes = append(changes, Change{
+					Kind: ChangeChanged, Symbol: symbol, Breaking: true,
+					Detail: fmt.Sprintf("%s -> %s", ov.Type, nv.Type),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+func valuesByName(values []Value) map[string]Value {
+	out := make(map[string]Value, len(values))
+	for _, v := range values {
+		out[v.Name] = v
+	}
+	return out
+}
+
+func diffType(name string, ot, nt Type) []Change {
+	var changes []Change
+
+	if ot.Kind != nt.Kind {
+		changes = append(changes, Change{
+			Kind: ChangeChanged, Symbol: "type " + name, Breaking: true,
+			Detail: fmt.Sprintf("kind changed from %s to %s", ot.Kind, nt.Kind),
+		})
+	}
+
+	iface := nt.Kind == TypeKindInterface || ot.Kind == TypeKindInterface
+
+	changes = append(changes, diffFields(name, ot.Fields, nt.Fields)...)
+	changes = append(changes, diffEmbedding(name, ot.Embedded, nt.Embedded, iface)...)
+	changes = append(changes, diffMethods(name, ot.Methods, nt.Methods, iface)...)
+
+	return changes
+}
+
+func diffFields(typeName string, oldFields, newFields []Field) []Change {
+	oldByName := make(map[string]Field, len(oldFields))
+	for _, f := range oldFields {
+		oldByName[f.Name] = f
+	}
+	newByName := make(map[string]Field, len(newFields))
+	for _, f := range newFields {
+		newByName[f.Name] = f
+	}
+
+	var changes []Change
+	for _, name := range unionNames(oldByName, newByName) {
+		of, oldOK := oldByName[name]
+		nf, newOK := newByName[name]
+		symbol := typeName + "." + name
+		switch {
+		case oldOK && !newOK:
+			if of.Exported {
+				changes = append(changes, Change{Kind: ChangeRemoved, Symbol: symbol, Breaking: true})
+			}
+		case !oldOK && newOK:
+			if nf.Exported {
+				changes = append(changes, Change{Kind: ChangeAdded, Symbol: symbol})
+			}
+		default:
+			if (of.Exported || nf.Exported) && (of.Type != nf.Type || of.Tag != nf.Tag) {
+				changes = append(changes, Change{
+					Kind: ChangeChanged, Symbol: symbol, Breaking: true,
+					Detail: fmt.Sprintf("%s -> %s", describeField(of), describeField(nf)),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+func describeField(f Field) string {
+	if f.Tag == "" {
+		return f.Type
+	}
+	return fmt.Sprintf("%s `%s`", f.Type, f.Tag)
+}
+
+// diffEmbedding compares a type's embedded types between old and new. For an
+// interface, embedding a new interface widens its method set exactly like
+// adding a method directly does, so iface mirrors diffMethods' Breaking
+// treatment of additions; embedding removal is always breaking.
+func diffEmbedding(typeName string, oldEmbedded, newEmbedded []string, iface bool) []Change {
+	oldSet := make(map[string]bool, len(oldEmbedded))
+	for _, e := range oldEmbedded {
+		oldSet[e] = true
+	}
+	newSet := make(map[string]bool, len(newEmbedded))
+	for _, e := range newEmbedded {
+		newSet[e] = true
+	}
+
+	var changes []Change
+	for _, e := range newEmbedded {
+		if !oldSet[e] {
+			changes = append(changes, Change{Kind: ChangeAdded, Symbol: typeName + " embeds " + e, Breaking: iface})
+		}
+	}
+	for _, e := range oldEmbedded {
+		if !newSet[e] {
+			changes = append(changes, Change{Kind: ChangeRemoved, Symbol: typeName + " embeds " + e, Breaking: true})
+		}
+	}
+	return changes
+}
+
+// diffMethods compares a type's method set between old and new. Interface
+// methods are breaking both ways: a removed method drops support existing
+// implementers relied on, and an added method demands implementers (in new)
+// didn't previously need to provide. A concrete type's added methods are
+// non-breaking; only removing an exported one, or changing a shared method's
+// signature, is.
+func diffMethods(typeName string, oldMethods, newMethods []Function, iface bool) []Change {
+	oldByName := functionsByName(oldMethods)
+	newByName := functionsByName(newMethods)
+
+	var changes []Change
+	for _, name := range unionNames(oldByName, newByName) {
+		of, oldOK := oldByName[name]
+		nf, newOK := newByName[name]
+		symbol := typeName + "." + name
+		switch {
+		case oldOK && !newOK:
+			if of.Exported {
+				changes = append(changes, Change{Kind: ChangeRemoved, Symbol: symbol, Breaking: true})
+			}
+		case !oldOK && newOK:
+			if nf.Exported {
+				changes = append(changes, Change{Kind: ChangeAdded, Symbol: symbol, Breaking: iface})
+			}
+		default:
+			if (of.Exported || nf.Exported) && signatureChanged(of, nf) {
+				changes = append(changes, Change{Kind: ChangeChanged, Symbol: symbol, Breaking: true, Detail: "signature change"})
+			}
+		}
+	}
+	return changes
+}
+
+func signatureChanged(of, nf Function) bool {
+	if of.Variadic != nf.Variadic {
+		return true
+	}
+	return !sameParams(of.Params, nf.Params) || !sameParams(of.Results, nf.Results)
+}
+
+func sameParams(a, b []Param) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+func packagesByImportPath(m *Model) map[string]Package {
+	out := map[string]Package{}
+	if m == nil {
+		return out
+	}
+	for _, pkg := range m.Packages {
+		out[pkg.ImportPath] = pkg
+	}
+	return out
+}
+
+func typesByName(pkg Package) map[string]Type {
+	out := make(map[string]Type, len(pkg.Types))
+	for _, t := range pkg.Types {
+		out[t.Name] = t
+	}
+	return out
+}
+
+func functionsByName(fns []Function) map[string]Function {
+	out := make(map[string]Function, len(fns))
+	for _, fn := range fns {
+		out[fn.Name] = fn
+	}
+	return out
+}
+
+// unionNames returns the sorted union of two maps' keys, regardless of
+// their value type.
+func unionNames[T any](a, b map[string]T) []string {
+	set := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		set[k] = struct{}{}
+	}
+	for k := range b {
+		set[k] = struct{}{}
+	}
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,36 @@
+package uml
+
+import "testing"
+
+func TestDiffEmbeddingNewInterfaceEmbedIsBreaking(t *testing.T) {
+	changes := diffEmbedding("Handler", []string{"io.Reader"}, []string{"io.Reader", "io.Writer"}, true)
+
+	if len(changes) != 1 {
+		t.Fatalf("want 1 change, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Kind != ChangeAdded || !changes[0].Breaking {
+		t.Errorf("adding an embedded interface should be a breaking addition, got %+v", changes[0])
+	}
+}
+
+func TestDiffEmbeddingNewStructEmbedIsNotBreaking(t *testing.T) {
+	changes := diffEmbedding("Config", nil, []string{"Base"}, false)
+
+	if len(changes) != 1 {
+		t.Fatalf("want 1 change, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Kind != ChangeAdded || changes[0].Breaking {
+		t.Errorf("adding an embedded struct should not be breaking, got %+v", changes[0])
+	}
+}
+
+func TestDiffEmbeddingRemovalIsAlwaysBreaking(t *testing.T) {
+	changes := diffEmbedding("Handler", []string{"io.Reader"}, nil, true)
+
+	if len(changes) != 1 {
+		t.Fatalf("want 1 change, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Kind != ChangeRemoved || !changes[0].Breaking {
+		t.Errorf("removing an embedded type should always be breaking, got %+v", changes[0])
+	}
+}
@@ -116,53 +116,253 @@ func GenerateJSON(root string, opts Options) ([]byte, error) {
 
 var errNoGoFiles = errors.New("no go files")
 
-func parseDir(fset *token.FileSet, modLocator *moduleLocator, dir, onlyFile, relBase string, opts Options) ([]Package, error) {
-	filter := func(info fs.FileInfo) bool {
-		name := info.Name()
+// candidateFiles lists the .go files in dir that pass the test/generated
+// filters, independent of any build context. Per-context build-constraint
+// matching happens afterwards in parseDir.
+func candidateFiles(dir, onlyFile string, opts Options) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errNoGoFiles
+		}
+		return nil, fmt.Errorf("read dir %s: %w", dir, err)
+	}
+
+	var out []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
 		if !strings.HasSuffix(name, ".go") {
-			return false
+			continue
 		}
 		if onlyFile != "" && name != onlyFile {
-			return false
+			continue
 		}
 		if !opts.IncludeTests && strings.HasSuffix(name, "_test.go") {
-			return false
+			continue
 		}
 		if !opts.IncludeGenerated {
 			isGen, err := isGeneratedFile(filepath.Join(dir, name))
 			if err == nil && isGen {
-				return false
+				continue
 			}
 		}
-		return true
+		out = append(out, name)
 	}
+	if len(out) == 0 {
+		return nil, errNoGoFiles
+	}
+	return out, nil
+}
 
-	parsed, err := parser.ParseDir(fset, dir, filter, parser.ParseComments)
+// parseDir parses dir once per configured BuildContext, keeping only the
+// files each context's build constraints allow, and merges the results into
+// one Package per package name. Declarations seen under only some contexts
+// are tagged accordingly instead of silently collapsed or dropped.
+func parseDir(fset *token.FileSet, modLocator *moduleLocator, dir, onlyFile, relBase string, opts Options) ([]Package, error) {
+	candidates, err := candidateFiles(dir, onlyFile, opts)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return nil, errNoGoFiles
+		return nil, err
+	}
+
+	merged := map[string]*Package{}
+	var order []string
+
+	for _, bc := range opts.Contexts {
+		files := matchFiles(dir, candidates, bc)
+		if len(files) == 0 {
+			continue
 		}
-		if strings.Contains(err.Error(), "no Go files") {
-			return nil, errNoGoFiles
+
+		wanted := make(map[string]struct{}, len(files))
+		for _, f := range files {
+			wanted[f] = struct{}{}
+		}
+		filter := func(info fs.FileInfo) bool {
+			_, ok := wanted[info.Name()]
+			return ok
+		}
+
+		parsed, err := parser.ParseDir(fset, dir, filter, parser.ParseComments)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) || strings.Contains(err.Error(), "no Go files") {
+				continue
+			}
+			return nil, fmt.Errorf("parse dir %s: %w", dir, err)
+		}
+
+		for pkgName, pkg := range parsed {
+			pkgModel, err := extractPackage(fset, modLocator, dir, pkgName, pkg, relBase)
+			if err != nil {
+				return nil, err
+			}
+			pkgModel = sortPackage(pkgModel)
+			mergeContext(merged, &order, pkgName, pkgModel, bc.String())
 		}
-		return nil, fmt.Errorf("parse dir %s: %w", dir, err)
 	}
-	if len(parsed) == 0 {
+
+	if len(merged) == 0 {
 		return nil, errNoGoFiles
 	}
 
-	var out []Package
-	for pkgName, pkg := range parsed {
-		pkgModel, err := extractPackage(fset, modLocator, dir, pkgName, pkg, relBase)
-		if err != nil {
-			return nil, err
-		}
-		pkgModel = sortPackage(pkgModel)
-		out = append(out, pkgModel)
+	out := make([]Package, 0, len(merged))
+	for _, name := range order {
+		out = append(out, *merged[name])
 	}
+	pruneUniversalConstraints(out, len(opts.Contexts))
 	return out, nil
 }
 
+// mergeContext folds a single context's extracted Package into the
+// accumulator for pkgName, tagging any new file, type, or function with
+// ctxName and widening the Constraints/Contexts of ones already seen under a
+// different context.
+func mergeContext(merged map[string]*Package, order *[]string, pkgName string, pkgModel Package, ctxName string) {
+	dst, ok := merged[pkgName]
+	if !ok {
+		dst = &Package{
+			Name:       pkgModel.Name,
+			ImportPath: pkgModel.ImportPath,
+			Dir:        pkgModel.Dir,
+		}
+		merged[pkgName] = dst
+		*order = append(*order, pkgName)
+	}
+
+	for _, f := range pkgModel.Files {
+		mergeFile(dst, f.Path, ctxName)
+	}
+	for _, t := range pkgModel.Types {
+		mergeType(dst, t, ctxName)
+	}
+	for _, fn := range pkgModel.Functions {
+		mergeFunction(dst, fn, ctxName)
+	}
+	for _, v := range pkgModel.Constants {
+		mergeValue(&dst.Constants, v, ctxName)
+	}
+	for _, v := range pkgModel.Variables {
+		mergeValue(&dst.Variables, v, ctxName)
+	}
+}
+
+func mergeFile(dst *Package, path, ctxName string) {
+	for i := range dst.Files {
+		if dst.Files[i].Path == path {
+			dst.Files[i].Contexts = appendUnique(dst.Files[i].Contexts, ctxName)
+			return
+		}
+	}
+	dst.Files = append(dst.Files, File{Path: path, Contexts: []string{ctxName}})
+}
+
+func mergeType(dst *Package, t Type, ctxName string) {
+	for i := range dst.Types {
+		if dst.Types[i].Name != t.Name {
+			continue
+		}
+		dst.Types[i].Constraints = appendUnique(dst.Types[i].Constraints, ctxName)
+		mergeMethods(&dst.Types[i], t.Methods, ctxName)
+		return
+	}
+	t.Constraints = []string{ctxName}
+	for i := range t.Methods {
+		t.Methods[i].Constraints = []string{ctxName}
+	}
+	dst.Types = append(dst.Types, t)
+}
+
+func mergeMethods(dstType *Type, methods []Function, ctxName string) {
+	for _, m := range methods {
+		found := false
+		for i := range dstType.Methods {
+			if dstType.Methods[i].Name == m.Name {
+				dstType.Methods[i].Constraints = appendUnique(dstType.Methods[i].Constraints, ctxName)
+				found = true
+				break
+			}
+		}
+		if !found {
+			m.Constraints = []string{ctxName}
+			dstType.Methods = append(dstType.Methods, m)
+		}
+	}
+}
+
+func mergeFunction(dst *Package, fn Function, ctxName string) {
+	for i := range dst.Functions {
+		if dst.Functions[i].Name == fn.Name && dst.Functions[i].Receiver == fn.Receiver {
+			dst.Functions[i].Constraints = appendUnique(dst.Functions[i].Constraints, ctxName)
+			return
+		}
+	}
+	fn.Constraints = []string{ctxName}
+	dst.Functions = append(dst.Functions, fn)
+}
+
+// mergeValue folds v into dst, matching by name the same way mergeFunction
+// does, since within one package a const/var name is unique regardless of
+// which build context it was observed under.
+func mergeValue(dst *[]Value, v Value, ctxName string) {
+	for i := range *dst {
+		if (*dst)[i].Name == v.Name {
+			(*dst)[i].Constraints = appendUnique((*dst)[i].Constraints, ctxName)
+			return
+		}
+	}
+	v.Constraints = []string{ctxName}
+	*dst = append(*dst, v)
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, e := range list {
+		if e == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+// pruneUniversalConstraints clears Constraints/Contexts on anything observed
+// under every evaluated context (totalContexts of them), since those are not
+// actually platform-specific.
+func pruneUniversalConstraints(pkgs []Package, totalContexts int) {
+	for pi := range pkgs {
+		for fi := range pkgs[pi].Files {
+			if len(pkgs[pi].Files[fi].Contexts) == totalContexts {
+				pkgs[pi].Files[fi].Contexts = nil
+			}
+		}
+		for ti := range pkgs[pi].Types {
+			if len(pkgs[pi].Types[ti].Constraints) == totalContexts {
+				pkgs[pi].Types[ti].Constraints = nil
+			}
+			for mi := range pkgs[pi].Types[ti].Methods {
+				if len(pkgs[pi].Types[ti].Methods[mi].Constraints) == totalContexts {
+					pkgs[pi].Types[ti].Methods[mi].Constraints = nil
+				}
+			}
+		}
+		for fi := range pkgs[pi].Functions {
+			if len(pkgs[pi].Functions[fi].Constraints) == totalContexts {
+				pkgs[pi].Functions[fi].Constraints = nil
+			}
+		}
+		for vi := range pkgs[pi].Constants {
+			if len(pkgs[pi].Constants[vi].Constraints) == totalContexts {
+				pkgs[pi].Constants[vi].Constraints = nil
+			}
+		}
+		for vi := range pkgs[pi].Variables {
+			if len(pkgs[pi].Variables[vi].Constraints) == totalContexts {
+				pkgs[pi].Variables[vi].Constraints = nil
+			}
+		}
+	}
+}
+
 func extractPackage(fset *token.FileSet, modLocator *moduleLocator, dir, pkgName string, pkg *ast.Package, relBase string) (Package, error) {
 	pkgModel := Package{
 		Name: pkgName,
@@ -184,7 +384,9 @@ func extractPackage(fset *token.FileSet, modLocator *moduleLocator, dir, pkgName
 		}
 	}
 
-	pkgModel.Files = packageFiles(relBase, dir, pkg)
+	for _, path := range packageFiles(relBase, dir, pkg) {
+		pkgModel.Files = append(pkgModel.Files, File{Path: path})
+	}
 
 	typesByName := map[string]int{}
 
@@ -218,6 +420,19 @@ func extractPackage(fset *token.FileSet, modLocator *moduleLocator, dir, pkgName
 		}
 	}
 
+	groupSeq := 0
+	for _, name := range sortedFileNames(pkg) {
+		file := pkg.Files[name]
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			extractValueDecl(fset, gen, groupSeq, typesByName, &pkgModel)
+			groupSeq++
+		}
+	}
+
 	for _, file := range pkg.Files {
 		for _, decl := range file.Decls {
 			fn, ok := decl.(*ast.FuncDecl)
@@ -258,12 +473,135 @@ func extractPackage(fset *token.FileSet, modLocator *moduleLocator, dir, pkgName
 	return pkgModel, nil
 }
 
+// extractValueDecl walks one top-level "const (...)" or "var (...)" GenDecl,
+// appending a Value to pkgModel.Constants or Variables for each name. In a
+// const block, a ValueSpec that omits its Values continues the previous
+// spec's type and values, exactly as Go's own const-block semantics do, so
+// those are carried forward here rather than left blank; var specs never
+// inherit from each other. When every spec in an iota-driven const group
+// shares the same named type, that type's EnumOf is filled in with the
+// group's member names so renderers can draw it as an enum.
+func extractValueDecl(fset *token.FileSet, gen *ast.GenDecl, group int, typesByName map[string]int, pkgModel *Package) {
+	kind := ValueKindVar
+	if gen.Tok == token.CONST {
+		kind = ValueKindConst
+	}
+
+	var lastType string
+	var lastValues []ast.Expr
+	var enumType string
+	var enumMembers []string
+	isEnum := kind == ValueKindConst
+
+	for _, spec := range gen.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		// A spec with no expression list repeats the immediately preceding
+		// spec's type and expressions verbatim (the classic iota pattern);
+		// one with its own expression list never inherits: it has the type
+		// it declares, or none. This repetition applies to const blocks only.
+		var typ string
+		values := vs.Values
+		switch {
+		case len(values) == 0 && kind == ValueKindConst:
+			typ, values = lastType, lastValues
+		case vs.Type != nil:
+			typ = exprString(fset, vs.Type)
+		}
+		lastType, lastValues = typ, values
+
+		usesIota := exprsContainIota(values)
+		if isEnum {
+			switch {
+			case typ == "" || !usesIota:
+				isEnum = false
+			case enumType == "":
+				enumType = typ
+			case enumType != typ:
+				isEnum = false
+			}
+		}
+
+		literal := ""
+		if len(values) > 0 {
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = exprString(fset, v)
+			}
+			literal = strings.Join(parts, ", ")
+		}
+
+		for _, name := range vs.Names {
+			if name.Name == "_" {
+				continue
+			}
+			v := Value{
+				Name:     name.Name,
+				Kind:     kind,
+				Type:     typ,
+				Value:    literal,
+				Exported: ast.IsExported(name.Name),
+				Doc:      docText(vs.Doc, gen.Doc, len(gen.Specs) == 1),
+				Group:    group,
+			}
+			if kind == ValueKindConst {
+				pkgModel.Constants = append(pkgModel.Constants, v)
+			} else {
+				pkgModel.Variables = append(pkgModel.Variables, v)
+			}
+			if isEnum {
+				enumMembers = append(enumMembers, name.Name)
+			}
+		}
+	}
+
+	if isEnum && len(enumMembers) > 0 {
+		if idx, ok := typesByName[enumType]; ok {
+			pkgModel.Types[idx].EnumOf = append(pkgModel.Types[idx].EnumOf, enumMembers...)
+		}
+	}
+}
+
+// exprsContainIota reports whether any expression in values references the
+// predeclared iota identifier, directly or as part of a larger expression
+// such as "1 << iota".
+func exprsContainIota(values []ast.Expr) bool {
+	for _, v := range values {
+		found := false
+		ast.Inspect(v, func(n ast.Node) bool {
+			if found {
+				return false
+			}
+			if id, ok := n.(*ast.Ident); ok && id.Name == "iota" {
+				found = true
+				return false
+			}
+			return true
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
 func sortPackage(pkg Package) Package {
-	sort.Strings(pkg.Files)
+	sort.Slice(pkg.Files, func(i, j int) bool {
+		return pkg.Files[i].Path < pkg.Files[j].Path
+	})
+	for i := range pkg.Files {
+		sort.Strings(pkg.Files[i].Contexts)
+	}
 
 	sort.Slice(pkg.Functions, func(i, j int) bool {
 		return pkg.Functions[i].Name < pkg.Functions[j].Name
 	})
+	for i := range pkg.Functions {
+		sort.Strings(pkg.Functions[i].Constraints)
+	}
 
 	sort.Slice(pkg.Types, func(i, j int) bool {
 		return pkg.Types[i].Name < pkg.Types[j].Name
@@ -273,9 +611,27 @@ func sortPackage(pkg Package) Package {
 			return pkg.Types[i].Fields[a].Name < pkg.Types[i].Fields[b].Name
 		})
 		sort.Strings(pkg.Types[i].Embedded)
+		sort.Strings(pkg.Types[i].Constraints)
 		sort.Slice(pkg.Types[i].Methods, func(a, b int) bool {
 			return pkg.Types[i].Methods[a].Name < pkg.Types[i].Methods[b].Name
 		})
+		for j := range pkg.Types[i].Methods {
+			sort.Strings(pkg.Types[i].Methods[j].Constraints)
+		}
+	}
+
+	sort.Slice(pkg.Constants, func(i, j int) bool {
+		return pkg.Constants[i].Name < pkg.Constants[j].Name
+	})
+	for i := range pkg.Constants {
+		sort.Strings(pkg.Constants[i].Constraints)
+	}
+
+	sort.Slice(pkg.Variables, func(i, j int) bool {
+		return pkg.Variables[i].Name < pkg.Variables[j].Name
+	})
+	for i := range pkg.Variables {
+		sort.Strings(pkg.Variables[i].Constraints)
 	}
 
 	return pkg
@@ -475,6 +831,19 @@ func toRelPath(baseDir, path string) string {
 	return filepath.ToSlash(rel)
 }
 
+// sortedFileNames returns pkg.Files' keys (the same map[string]*ast.File
+// parser.ParseDir keys by filename) in sorted order, so callers that assign
+// sequence numbers while walking the package don't inherit Go's randomized
+// map iteration order.
+func sortedFileNames(pkg *ast.Package) []string {
+	names := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func packageFiles(relBase, dir string, pkg *ast.Package) []string {
 	files := make([]string, 0, len(pkg.Files))
 	for file := range pkg.Files {
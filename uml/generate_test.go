@@ -0,0 +1,94 @@
+package uml
+
+import "testing"
+
+func TestGenerateConstIotaGroupCarriesTypeAndValue(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "colors.go", `package fixture
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+const Pi = 3.14
+`)
+
+	model, err := Generate(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(model.Packages) != 1 {
+		t.Fatalf("want 1 package, got %d", len(model.Packages))
+	}
+
+	consts := valuesByName(model.Packages[0].Constants)
+
+	red, ok := consts["Red"]
+	if !ok {
+		t.Fatal("Red not found")
+	}
+	if red.Type != "Color" || red.Value != "iota" {
+		t.Errorf("Red: want type Color value iota, got type %q value %q", red.Type, red.Value)
+	}
+
+	green, ok := consts["Green"]
+	if !ok {
+		t.Fatal("Green not found")
+	}
+	if green.Type != "Color" || green.Value != "iota" {
+		t.Errorf("Green should inherit Red's type and expression, got type %q value %q", green.Type, green.Value)
+	}
+	if green.Group != red.Group {
+		t.Errorf("Green should share Red's group, got %d vs %d", green.Group, red.Group)
+	}
+
+	pi, ok := consts["Pi"]
+	if !ok {
+		t.Fatal("Pi not found")
+	}
+	if pi.Type != "" {
+		t.Errorf("Pi declares no type and has its own expression, want empty type, got %q", pi.Type)
+	}
+	if pi.Group == red.Group {
+		t.Errorf("Pi is a separate const block and should not share Red's group")
+	}
+}
+
+func TestGenerateConstGroupOrderIsDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.go", `package fixture
+
+const A = 1
+`)
+	writeFile(t, dir, "b.go", `package fixture
+
+const B = 2
+`)
+	writeFile(t, dir, "c.go", `package fixture
+
+const C = 3
+`)
+
+	var firstGroups []int
+	for i := 0; i < 5; i++ {
+		model, err := Generate(dir, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		consts := valuesByName(model.Packages[0].Constants)
+		groups := []int{consts["A"].Group, consts["B"].Group, consts["C"].Group}
+		if i == 0 {
+			firstGroups = groups
+			continue
+		}
+		for j, g := range groups {
+			if g != firstGroups[j] {
+				t.Fatalf("run %d: group assignment changed between runs: %v vs %v", i, groups, firstGroups)
+			}
+		}
+	}
+}
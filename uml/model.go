@@ -18,9 +18,25 @@ type Package struct {
 	Name       string     `json:"name"`
 	ImportPath string     `json:"import_path,omitempty"`
 	Dir        string     `json:"dir"`
-	Files      []string   `json:"files,omitempty"`
+	Files      []File     `json:"files,omitempty"`
 	Types      []Type     `json:"types,omitempty"`
 	Functions  []Function `json:"functions,omitempty"`
+	Constants  []Value    `json:"constants,omitempty"`
+	Variables  []Value    `json:"variables,omitempty"`
+
+	// Imports lists the import paths directly imported by this package.
+	// Only populated by LoadPackages, which resolves them via
+	// golang.org/x/tools/go/packages instead of source text.
+	Imports []string `json:"imports,omitempty"`
+}
+
+// File is a source file within a Package. Contexts lists the BuildContext
+// labels (e.g. "linux/amd64") the file was observed under; it is left empty
+// when the file is present under every evaluated context, i.e. it isn't
+// platform-specific.
+type File struct {
+	Path     string   `json:"path"`
+	Contexts []string `json:"contexts,omitempty"`
 }
 
 type TypeKind string
@@ -42,6 +58,24 @@ type Type struct {
 	Fields   []Field    `json:"fields,omitempty"`
 	Embedded []string   `json:"embedded,omitempty"`
 	Methods  []Function `json:"methods,omitempty"`
+
+	// Constraints lists the BuildContext labels this type was observed
+	// under; empty means it's present under every evaluated context.
+	Constraints []string `json:"constraints,omitempty"`
+
+	// Implements and ImplementedBy are only populated by GenerateTyped, which
+	// type-checks the package with go/types. Implements lists the qualified
+	// names (import/path.Name) of interfaces whose method set this type (or
+	// its pointer) satisfies; ImplementedBy is the inverse, populated on
+	// interface types.
+	Implements    []string `json:"implements,omitempty"`
+	ImplementedBy []string `json:"implemented_by,omitempty"`
+
+	// EnumOf lists the member names of an iota-based constant group that
+	// shares this type, in declaration order, so renderers can draw it as an
+	// enum-style class instead of an empty one. Populated by extractPackage;
+	// left nil for types no const group targets.
+	EnumOf []string `json:"enum_of,omitempty"`
 }
 
 type Field struct {
@@ -50,6 +84,10 @@ type Field struct {
 	Tag      string `json:"tag,omitempty"`
 	Embedded bool   `json:"embedded,omitempty"`
 	Exported bool   `json:"exported,omitempty"`
+
+	// QualifiedType is the fully-qualified form of Type (e.g. "time.Duration"
+	// instead of "Duration"), populated only by GenerateTyped.
+	QualifiedType string `json:"qualified_type,omitempty"`
 }
 
 type Function struct {
@@ -61,14 +99,59 @@ type Function struct {
 	Params     []Param     `json:"params,omitempty"`
 	Results    []Param     `json:"results,omitempty"`
 	Variadic   bool        `json:"variadic,omitempty"`
+
+	// Constraints lists the BuildContext labels this function was observed
+	// under; empty means it's present under every evaluated context.
+	Constraints []string `json:"constraints,omitempty"`
 }
 
 type Param struct {
 	Name string `json:"name,omitempty"`
 	Type string `json:"type"`
+
+	// QualifiedType is the fully-qualified form of Type, populated only by
+	// GenerateTyped.
+	QualifiedType string `json:"qualified_type,omitempty"`
 }
 
 type TypeParam struct {
 	Name       string `json:"name"`
 	Constraint string `json:"constraint,omitempty"`
 }
+
+type ValueKind string
+
+const (
+	ValueKindConst ValueKind = "const"
+	ValueKindVar   ValueKind = "var"
+)
+
+// Value is a package-level const or var declaration.
+type Value struct {
+	Name     string    `json:"name"`
+	Kind     ValueKind `json:"kind"`
+	Type     string    `json:"type,omitempty"`
+	Exported bool      `json:"exported"`
+	Doc      string    `json:"doc,omitempty"`
+
+	// Value is the literal source form of the initializer (e.g. "1 << 2" or
+	// "iota"), or empty for a var with no initializer. When a spec omits its
+	// initializer to continue an iota group, this repeats the previous
+	// spec's expression, matching Go's own const-block semantics.
+	Value string `json:"value,omitempty"`
+
+	// Group is a stable ID shared by every Value declared in the same
+	// GenDecl (e.g. one "const (...)" block), so callers can reconstruct
+	// iota-based enum families even after Constants/Variables is sorted by
+	// name.
+	Group int `json:"group"`
+
+	// QualifiedType is the fully-qualified form of Type (e.g.
+	// "time.Duration" instead of "Duration"), populated only by
+	// GenerateTyped.
+	QualifiedType string `json:"qualified_type,omitempty"`
+
+	// Constraints lists the BuildContext labels this value was observed
+	// under; empty means it's present under every evaluated context.
+	Constraints []string `json:"constraints,omitempty"`
+}
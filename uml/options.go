@@ -5,6 +5,13 @@ type Options struct {
 	IncludeGenerated bool
 	ExcludeDirNames  []string
 
+	// Contexts is the matrix of GOOS/GOARCH/cgo combinations used to
+	// evaluate build constraints. Files and declarations are tagged with
+	// every context they are observed under (Package.Files[i].Contexts,
+	// Type.Constraints, Function.Constraints), and left untagged when
+	// present under all of them. Defaults to DefaultContexts().
+	Contexts []BuildContext
+
 	Indent string
 }
 
@@ -19,6 +26,9 @@ func (o Options) withDefaults() Options {
 			"vendor",
 		}
 	}
+	if len(o.Contexts) == 0 {
+		o.Contexts = DefaultContexts()
+	}
 	if o.Indent == "" {
 		o.Indent = "  "
 	}
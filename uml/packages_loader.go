@@ -0,0 +1,87 @@
+package uml
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const loadPackagesMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps
+
+// LoadPackages resolves patterns (standard package patterns such as "./...",
+// "example.com/foo/...", or a go.work root) via golang.org/x/tools/go/packages
+// instead of the nearest-go.mod heuristic moduleLocator uses, so nested
+// modules, replace directives, workspace mode, and vendored dependencies
+// resolve import paths the same way "go build" would. Package.Imports is
+// populated from the resolved import graph, which Generate cannot do on its
+// own.
+func LoadPackages(patterns []string, opts Options) ([]Package, error) {
+	opts = opts.withDefaults()
+
+	cfg := &packages.Config{
+		Mode:  loadPackagesMode,
+		Tests: opts.IncludeTests,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages %v: %w", patterns, err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("load packages %v: one or more packages failed to load", patterns)
+	}
+
+	out := make([]Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		pkgModel, err := extractLoadedPackage(pkg)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sortPackage(pkgModel))
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ImportPath < out[j].ImportPath })
+	return out, nil
+}
+
+// extractLoadedPackage adapts a *packages.Package onto the same AST-walking
+// extractPackage used by Generate, then overlays the import path and import
+// graph that only go/packages can resolve correctly.
+func extractLoadedPackage(pkg *packages.Package) (Package, error) {
+	if len(pkg.Syntax) == 0 {
+		return Package{Name: pkg.Name, ImportPath: pkg.PkgPath}, nil
+	}
+
+	dir := filepath.Dir(pkg.CompiledGoFiles[0])
+	relBase := dir
+	if pkg.Module != nil {
+		relBase = pkg.Module.Dir
+	}
+
+	files := make(map[string]*ast.File, len(pkg.Syntax))
+	for i, file := range pkg.Syntax {
+		files[filepath.Base(pkg.CompiledGoFiles[i])] = file
+	}
+	astPkg := &ast.Package{Name: pkg.Name, Files: files}
+
+	pkgModel, err := extractPackage(pkg.Fset, newModuleLocator(), dir, pkg.Name, astPkg, relBase)
+	if err != nil {
+		return Package{}, fmt.Errorf("extract %s: %w", pkg.PkgPath, err)
+	}
+
+	pkgModel.ImportPath = pkg.PkgPath
+	pkgModel.Imports = importPaths(pkg)
+	return pkgModel, nil
+}
+
+func importPaths(pkg *packages.Package) []string {
+	out := make([]string, 0, len(pkg.Imports))
+	for path := range pkg.Imports {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return out
+}
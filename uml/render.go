@@ -0,0 +1,170 @@
+package uml
+
+import "strings"
+
+// RenderOptions controls how RenderPlantUML and RenderMermaid translate a
+// Model into diagram source.
+type RenderOptions struct {
+	// IncludeUnexported also emits unexported fields and methods. By default
+	// only exported members are shown.
+	IncludeUnexported bool
+
+	// IncludeSignatures emits full method signatures (params and results)
+	// instead of just the method name.
+	IncludeSignatures bool
+
+	// GroupByPackage wraps each package's types in a named package block
+	// (PlantUML "package" block; ignored by Mermaid, which has no package
+	// grouping construct).
+	GroupByPackage bool
+}
+
+// diagramEdge is a relationship between two types, shared by both renderers.
+type diagramEdge struct {
+	kind  edgeKind
+	from  string
+	to    string
+	label string
+}
+
+type edgeKind int
+
+const (
+	edgeExtends edgeKind = iota
+	edgeImplements
+	edgeReference
+)
+
+// collectEdges walks every package in the model and returns the embedding,
+// interface-implementation, and field-reference edges between in-model
+// types. known is the set of classIDs the model actually defines, so edges
+// are only drawn to types the diagram can render.
+func collectEdges(m *Model, known map[string]bool) []diagramEdge {
+	var edges []diagramEdge
+
+	for _, pkg := range m.Packages {
+		for _, t := range pkg.Types {
+			id := classID(pkg, t)
+
+			for _, parent := range t.Embedded {
+				// Embedded/Field type text is raw source (e.g. "Foo" or
+				// "otherpkg.Foo"), not an import path, so it can only be
+				// resolved reliably against the declaring package itself.
+				candidate := localClassID(pkg, baseTypeName(parent))
+				if known[candidate] {
+					edges = append(edges, diagramEdge{kind: edgeExtends, from: candidate, to: id})
+				}
+			}
+			for _, iface := range t.Implements {
+				// Implements/ImplementedBy are already "import/path.Name",
+				// populated by GenerateTyped straight from go/types, so they
+				// need no further qualification.
+				if known[iface] {
+					edges = append(edges, diagramEdge{kind: edgeImplements, from: iface, to: id})
+				}
+			}
+			for _, f := range t.Fields {
+				candidate := localClassID(pkg, baseTypeName(f.Type))
+				if candidate == id || !known[candidate] {
+					continue
+				}
+				edges = append(edges, diagramEdge{kind: edgeReference, from: id, to: candidate, label: f.Name})
+			}
+		}
+	}
+
+	return edges
+}
+
+// classID is the identifier a Type is tracked and referenced by: its
+// package's import path plus its name, so two packages that both declare
+// e.g. "Handler" don't collide into one ambiguous class/edge.
+func classID(pkg Package, t Type) string {
+	return localClassID(pkg, t.Name)
+}
+
+func localClassID(pkg Package, name string) string {
+	if pkg.ImportPath == "" {
+		return name
+	}
+	return pkg.ImportPath + "." + name
+}
+
+// knownTypeNames returns the set of classIDs defined anywhere in the model,
+// used to decide whether a field or embedded type is worth drawing an edge
+// to (as opposed to a stdlib or third-party type).
+func knownTypeNames(m *Model) map[string]bool {
+	known := map[string]bool{}
+	for _, pkg := range m.Packages {
+		for _, t := range pkg.Types {
+			known[classID(pkg, t)] = true
+		}
+	}
+	return known
+}
+
+// baseTypeName strips pointer, slice, map, and qualifier decoration from a
+// raw source type expression (e.g. "*[]pkg.Foo" -> "Foo").
+func baseTypeName(raw string) string {
+	s := strings.TrimSpace(raw)
+	for {
+		switch {
+		case strings.HasPrefix(s, "*"):
+			s = s[1:]
+		case strings.HasPrefix(s, "[]"):
+			s = s[2:]
+		case strings.HasPrefix(s, "..."):
+			s = s[3:]
+		default:
+			if idx := strings.LastIndex(s, "]"); strings.HasPrefix(s, "[") && idx >= 0 {
+				s = s[idx+1:]
+				continue
+			}
+			if idx := strings.LastIndex(s, "]"); strings.HasPrefix(s, "map[") && idx >= 0 {
+				s = s[idx+1:]
+				continue
+			}
+			if idx := strings.LastIndex(s, "."); idx >= 0 {
+				s = s[idx+1:]
+			}
+			return s
+		}
+	}
+}
+
+func visibilitySymbol(exported bool) string {
+	if exported {
+		return "+"
+	}
+	return "-"
+}
+
+func includeMember(exported bool, opts RenderOptions) bool {
+	return exported || opts.IncludeUnexported
+}
+
+func methodSignature(fn Function, opts RenderOptions) string {
+	if !opts.IncludeSignatures {
+		return fn.Name + "()"
+	}
+
+	params := make([]string, 0, len(fn.Params))
+	for _, p := range fn.Params {
+		params = append(params, strings.TrimSpace(p.Name+" "+p.Type))
+	}
+
+	results := make([]string, 0, len(fn.Results))
+	for _, r := range fn.Results {
+		results = append(results, r.Type)
+	}
+
+	sig := fn.Name + "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return sig
+	case 1:
+		return sig + " " + results[0]
+	default:
+		return sig + " (" + strings.Join(results, ", ") + ")"
+	}
+}
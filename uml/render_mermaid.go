@@ -0,0 +1,86 @@
+package uml
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+var mermaidIDPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// RenderMermaid walks m and produces Mermaid classDiagram source, mirroring
+// RenderPlantUML's class/interface/enum blocks and edges. Mermaid has no
+// package grouping construct, so opts.GroupByPackage is ignored here.
+func RenderMermaid(m *Model, opts RenderOptions) ([]byte, error) {
+	known := knownTypeNames(m)
+
+	var buf bytes.Buffer
+	buf.WriteString("classDiagram\n")
+
+	for _, pkg := range m.Packages {
+		for _, t := range pkg.Types {
+			writeMermaidType(&buf, pkg, t, opts)
+		}
+	}
+
+	for _, edge := range collectEdges(m, known) {
+		writeMermaidEdge(&buf, edge)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeMermaidType(buf *bytes.Buffer, pkg Package, t Type, opts RenderOptions) {
+	id := classID(pkg, t)
+	fmt.Fprintf(buf, "  class %s[\"%s\"] {\n", mermaidID(id), id)
+	switch {
+	case t.Kind == TypeKindInterface:
+		fmt.Fprintf(buf, "    <<interface>>\n")
+	case len(t.EnumOf) > 0:
+		fmt.Fprintf(buf, "    <<enumeration>>\n")
+	}
+
+	for _, name := range t.EnumOf {
+		fmt.Fprintf(buf, "    %s\n", name)
+	}
+
+	for _, f := range t.Fields {
+		if !includeMember(f.Exported, opts) {
+			continue
+		}
+		fmt.Fprintf(buf, "    %s%s %s\n", visibilitySymbol(f.Exported), f.Type, f.Name)
+	}
+
+	for _, fn := range t.Methods {
+		if !includeMember(fn.Exported, opts) {
+			continue
+		}
+		fmt.Fprintf(buf, "    %s%s\n", visibilitySymbol(fn.Exported), methodSignature(fn, opts))
+	}
+
+	buf.WriteString("  }\n")
+}
+
+func writeMermaidEdge(buf *bytes.Buffer, edge diagramEdge) {
+	from, to := mermaidID(edge.from), mermaidID(edge.to)
+	switch edge.kind {
+	case edgeExtends:
+		fmt.Fprintf(buf, "  %s <|-- %s\n", from, to)
+	case edgeImplements:
+		fmt.Fprintf(buf, "  %s <|.. %s\n", from, to)
+	case edgeReference:
+		if edge.label != "" {
+			fmt.Fprintf(buf, "  %s --> %s : %s\n", from, to, edge.label)
+		} else {
+			fmt.Fprintf(buf, "  %s --> %s\n", from, to)
+		}
+	}
+}
+
+// mermaidID sanitizes a classID into a valid Mermaid node identifier:
+// Mermaid identifiers can't contain "/" or ".", so a package-qualified
+// classID is rewritten to a safe id and given its original value as the
+// node's quoted label instead (see writeMermaidType).
+func mermaidID(id string) string {
+	return mermaidIDPattern.ReplaceAllString(id, "_")
+}
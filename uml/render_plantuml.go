@@ -0,0 +1,100 @@
+package uml
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// RenderPlantUML walks m and produces PlantUML class diagram source: one
+// class/interface block per Type (rendered with an <<enum>> stereotype and
+// its member names when Type.EnumOf is set), with <|-- edges for embedding,
+// <|.. edges for interface implementation (populated by GenerateTyped), and
+// --> edges for field references to other in-model types.
+func RenderPlantUML(m *Model, opts RenderOptions) ([]byte, error) {
+	known := knownTypeNames(m)
+
+	var buf bytes.Buffer
+	buf.WriteString("@startuml\n")
+
+	for _, pkg := range m.Packages {
+		if len(pkg.Types) == 0 {
+			continue
+		}
+
+		indent := ""
+		if opts.GroupByPackage {
+			fmt.Fprintf(&buf, "package %q {\n", pkg.Name)
+			indent = "  "
+		}
+
+		for _, t := range pkg.Types {
+			writePlantUMLType(&buf, pkg, t, indent, opts)
+		}
+
+		if opts.GroupByPackage {
+			buf.WriteString("}\n")
+		}
+	}
+
+	for _, edge := range collectEdges(m, known) {
+		writePlantUMLEdge(&buf, edge)
+	}
+
+	buf.WriteString("@enduml\n")
+	return buf.Bytes(), nil
+}
+
+func writePlantUMLType(buf *bytes.Buffer, pkg Package, t Type, indent string, opts RenderOptions) {
+	keyword := "class"
+	stereotype := ""
+	switch {
+	case t.Kind == TypeKindInterface:
+		keyword = "interface"
+	case len(t.EnumOf) > 0:
+		stereotype = " <<enum>>"
+	}
+	fmt.Fprintf(buf, "%s%s %s%s {\n", indent, keyword, plantUMLID(classID(pkg, t)), stereotype)
+
+	for _, name := range t.EnumOf {
+		fmt.Fprintf(buf, "%s  %s\n", indent, name)
+	}
+
+	for _, f := range t.Fields {
+		if !includeMember(f.Exported, opts) {
+			continue
+		}
+		fmt.Fprintf(buf, "%s  %s%s : %s\n", indent, visibilitySymbol(f.Exported), f.Name, f.Type)
+	}
+
+	for _, fn := range t.Methods {
+		if !includeMember(fn.Exported, opts) {
+			continue
+		}
+		fmt.Fprintf(buf, "%s  %s%s\n", indent, visibilitySymbol(fn.Exported), methodSignature(fn, opts))
+	}
+
+	fmt.Fprintf(buf, "%s}\n", indent)
+}
+
+func writePlantUMLEdge(buf *bytes.Buffer, edge diagramEdge) {
+	from, to := plantUMLID(edge.from), plantUMLID(edge.to)
+	switch edge.kind {
+	case edgeExtends:
+		fmt.Fprintf(buf, "%s <|-- %s\n", from, to)
+	case edgeImplements:
+		fmt.Fprintf(buf, "%s <|.. %s\n", from, to)
+	case edgeReference:
+		if edge.label != "" {
+			fmt.Fprintf(buf, "%s --> %s : %s\n", from, to, edge.label)
+		} else {
+			fmt.Fprintf(buf, "%s --> %s\n", from, to)
+		}
+	}
+}
+
+// plantUMLID quotes a classID so package-qualified identifiers (which
+// contain "/" and "." and would otherwise be parsed as member access) are
+// treated as a single PlantUML identifier.
+func plantUMLID(id string) string {
+	return fmt.Sprintf("%q", id)
+}
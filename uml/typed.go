@@ -0,0 +1,268 @@
+package uml
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// typedLoadMode is the packages.Load mode used by GenerateTyped. It asks for
+// full type information (not just syntax) so that go/types can resolve
+// imports, build tags, and cgo the same way the real compiler would.
+const typedLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps
+
+// GenerateTyped runs the same AST-based extraction as Generate, then
+// type-checks every package with go/types (via golang.org/x/tools/go/packages
+// in typedLoadMode, which also loads dependencies) and augments the resulting Model with fully-qualified
+// type identifiers and interface-implementation information that cannot be
+// recovered from source text alone.
+func GenerateTyped(root string, opts Options) (*Model, error) {
+	opts = opts.withDefaults()
+
+	model, err := Generate(root, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("abs path: %w", err)
+	}
+	rootInfo, err := os.Stat(rootAbs)
+	if err != nil {
+		return nil, fmt.Errorf("stat root: %w", err)
+	}
+
+	// A single-file root (as Generate also supports via baseDir/onlyFile)
+	// isn't a valid "./..." pattern, so load just the package containing
+	// that file instead of every package under its directory.
+	dir := rootAbs
+	pattern := "./..."
+	if !rootInfo.IsDir() {
+		dir = filepath.Dir(rootAbs)
+		pattern = "file=" + rootAbs
+	}
+
+	cfg := &packages.Config{
+		Mode:  typedLoadMode,
+		Dir:   dir,
+		Tests: opts.IncludeTests,
+	}
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("load packages for type-checking: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("type-check %s: one or more packages failed to compile", root)
+	}
+
+	byImportPath := make(map[string]*packages.Package, len(pkgs))
+	packages.Visit(pkgs, func(pkg *packages.Package) bool {
+		byImportPath[pkg.PkgPath] = pkg
+		return true
+	}, nil)
+
+	qualifyModel(model, byImportPath)
+	annotateImplementations(model, byImportPath)
+
+	return model, nil
+}
+
+// qualifyModel fills in QualifiedType on every Field and Param in the model
+// using the corresponding go/types object, so callers get e.g. "time.Duration"
+// instead of the bare source text "Duration".
+func qualifyModel(model *Model, byImportPath map[string]*packages.Package) {
+	for pi := range model.Packages {
+		pkgModel := &model.Packages[pi]
+		pkg, ok := byImportPath[pkgModel.ImportPath]
+		if !ok || pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+
+		for ti := range pkgModel.Types {
+			t := &pkgModel.Types[ti]
+			obj := scope.Lookup(t.Name)
+			named, ok := obj.(*types.TypeName)
+			if !ok {
+				continue
+			}
+			qualifyFields(t, named)
+			qualifyMethods(t.Methods, lookupMethodSignatures(named.Type()))
+		}
+
+		for fi := range pkgModel.Functions {
+			fn := &pkgModel.Functions[fi]
+			obj := scope.Lookup(fn.Name)
+			funcObj, ok := obj.(*types.Func)
+			if !ok {
+				continue
+			}
+			qualifySignature(fn, funcObj.Type().(*types.Signature))
+		}
+
+		qualifier := types.RelativeTo(nil)
+		qualifyValues(pkgModel.Constants, scope, qualifier)
+		qualifyValues(pkgModel.Variables, scope, qualifier)
+	}
+}
+
+// qualifyValues fills in QualifiedType on each Value using the go/types
+// object it resolves to in scope, since a bare const/var declaration (unlike
+// a struct field) has no local source type to fall back on when it's
+// inferred from its initializer.
+func qualifyValues(values []Value, scope *types.Scope, qualifier types.Qualifier) {
+	for i := range values {
+		obj := scope.Lookup(values[i].Name)
+		if obj == nil {
+			continue
+		}
+		typ := obj.Type()
+		if typ == nil {
+			continue
+		}
+		values[i].QualifiedType = types.TypeString(typ, qualifier)
+	}
+}
+
+func qualifyFields(t *Type, named *types.TypeName) {
+	st, ok := named.Type().Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	byName := make(map[string]*types.Var, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		byName[st.Field(i).Name()] = st.Field(i)
+	}
+	qualifier := types.RelativeTo(nil)
+	for fi := range t.Fields {
+		v, ok := byName[t.Fields[fi].Name]
+		if !ok {
+			continue
+		}
+		t.Fields[fi].QualifiedType = types.TypeString(v.Type(), qualifier)
+	}
+}
+
+// lookupMethodSignatures returns the methods of typ keyed by method name,
+// covering both interface method sets and concrete types, for which the
+// method set of *typ is used so pointer-receiver methods are included.
+func lookupMethodSignatures(typ types.Type) map[string]*types.Signature {
+	out := map[string]*types.Signature{}
+	if iface, ok := typ.Underlying().(*types.Interface); ok {
+		for i := 0; i < iface.NumMethods(); i++ {
+			m := iface.Method(i)
+			out[m.Name()] = m.Type().(*types.Signature)
+		}
+		return out
+	}
+	mset := types.NewMethodSet(types.NewPointer(typ))
+	for i := 0; i < mset.Len(); i++ {
+		fn := mset.At(i).Obj().(*types.Func)
+		out[fn.Name()] = fn.Type().(*types.Signature)
+	}
+	return out
+}
+
+func qualifyMethods(methods []Function, sigs map[string]*types.Signature) {
+	for mi := range methods {
+		sig, ok := sigs[methods[mi].Name]
+		if !ok {
+			continue
+		}
+		qualifySignature(&methods[mi], sig)
+	}
+}
+
+func qualifySignature(fn *Function, sig *types.Signature) {
+	qualifyTuple(fn.Params, sig.Params())
+	qualifyTuple(fn.Results, sig.Results())
+}
+
+func qualifyTuple(params []Param, tuple *types.Tuple) {
+	if tuple == nil {
+		return
+	}
+	n := tuple.Len()
+	if n != len(params) {
+		// Variadic params and receivers can shift indices; best-effort only.
+		n = min(n, len(params))
+	}
+	for i := 0; i < n; i++ {
+		params[i].QualifiedType = types.TypeString(tuple.At(i).Type(), types.RelativeTo(nil))
+	}
+}
+
+// annotateImplementations computes Type.Implements and Type.ImplementedBy
+// across every loaded package by checking every named type's method set (and
+// its pointer's) against every named interface in scope.
+func annotateImplementations(model *Model, byImportPath map[string]*packages.Package) {
+	type named struct {
+		qualifiedName string
+		obj           *types.TypeName
+	}
+
+	var allTypes []named
+	var allIfaces []named
+
+	for _, pkg := range byImportPath {
+		if pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			n := named{qualifiedName: pkg.PkgPath + "." + tn.Name(), obj: tn}
+			allTypes = append(allTypes, n)
+			if _, ok := tn.Type().Underlying().(*types.Interface); ok {
+				allIfaces = append(allIfaces, n)
+			}
+		}
+	}
+
+	implements := map[string][]string{}
+	implementedBy := map[string][]string{}
+
+	for _, t := range allTypes {
+		for _, iface := range allIfaces {
+			if t.qualifiedName == iface.qualifiedName {
+				continue
+			}
+			ifaceType, ok := iface.obj.Type().Underlying().(*types.Interface)
+			if !ok {
+				continue
+			}
+			if types.Implements(t.obj.Type(), ifaceType) || types.Implements(types.NewPointer(t.obj.Type()), ifaceType) {
+				implements[t.qualifiedName] = append(implements[t.qualifiedName], iface.qualifiedName)
+				implementedBy[iface.qualifiedName] = append(implementedBy[iface.qualifiedName], t.qualifiedName)
+			}
+		}
+	}
+
+	for pi := range model.Packages {
+		pkgModel := &model.Packages[pi]
+		for ti := range pkgModel.Types {
+			t := &pkgModel.Types[ti]
+			qualifiedName := pkgModel.ImportPath + "." + t.Name
+			t.Implements = sortedCopy(implements[qualifiedName])
+			t.ImplementedBy = sortedCopy(implementedBy[qualifiedName])
+		}
+	}
+}
+
+func sortedCopy(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}